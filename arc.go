@@ -0,0 +1,336 @@
+package lru
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// ARCCache is a scan-resistant cache implementing Adaptive Replacement
+// Cache (ARC). It keeps four lists: T1/T2 hold real entries split by
+// recency vs. frequency, and B1/B2 are key-only ghost lists remembering
+// recent evictions from T1/T2 respectively. An adaptive parameter p in
+// [0, size] is the target size of T1; a hit on B1 grows p (favor
+// recency), a hit on B2 shrinks it (favor frequency), so the cache
+// self-tunes to the workload instead of using a fixed ratio like 2Q.
+type ARCCache struct {
+	sync.RWMutex
+	// OnEvicted optionally specifics a callback function to be executed
+	// when an entry's value is purged from the cache, whether evicted
+	// outright or demoted to a ghost list.
+	OnEvicted func(key Key, value interface{})
+
+	size int
+	p    int // target size of T1
+
+	t1 *list.List // *arcEntry, recent
+	t2 *list.List // *arcEntry, frequent
+	b1 *list.List // Key, ghost of T1
+	b2 *list.List // Key, ghost of T2
+
+	t1m map[Key]*list.Element
+	t2m map[Key]*list.Element
+	b1m map[Key]*list.Element
+	b2m map[Key]*list.Element
+
+	expire int64
+}
+
+type arcEntry struct {
+	key    Key
+	value  interface{}
+	expire int64
+}
+
+func (e *arcEntry) isExpired() bool {
+	if e.expire == 0 {
+		return false
+	}
+	return e.expire < time.Now().Unix()
+}
+
+// NewARCCache creates a new ARCCache holding up to size entries.
+// If expired is zero, entries never expire.
+func NewARCCache(size int, expired int64) *ARCCache {
+	c := &ARCCache{
+		size:   max(1, size),
+		t1:     list.New(),
+		t2:     list.New(),
+		b1:     list.New(),
+		b2:     list.New(),
+		t1m:    make(map[Key]*list.Element),
+		t2m:    make(map[Key]*list.Element),
+		b1m:    make(map[Key]*list.Element),
+		b2m:    make(map[Key]*list.Element),
+		expire: expired,
+	}
+	if c.expire > 0 {
+		go c.cleanExpired()
+	}
+	return c
+}
+
+// cleans expired entries performing minimal checks
+func (c *ARCCache) cleanExpired() {
+	for {
+		c.Lock()
+		if e := c.t1.Back(); e != nil && e.Value.(*arcEntry).isExpired() {
+			c.removeElement(c.t1, c.t1m, e)
+		} else if e := c.t2.Back(); e != nil && e.Value.(*arcEntry).isExpired() {
+			c.removeElement(c.t2, c.t2m, e)
+		}
+		c.Unlock()
+		time.Sleep(time.Duration(c.expire) * time.Second)
+	}
+}
+
+// Set a value to the cache. Key and value is required.
+func (c *ARCCache) Set(key Key, value interface{}) (bool, error) {
+	c.Lock()
+	defer c.Unlock()
+	var expire int64
+	if c.expire > 0 {
+		expire = time.Now().Unix() + c.expire
+	}
+
+	// Case I: already cached in T1 or T2 -> update value, promote to T2 MRU.
+	if ele, ok := c.t1m[key]; ok {
+		e := ele.Value.(*arcEntry)
+		e.value, e.expire = value, expire
+		c.t1.Remove(ele)
+		delete(c.t1m, key)
+		c.t2m[key] = c.t2.PushFront(e)
+		return true, nil
+	}
+	if ele, ok := c.t2m[key]; ok {
+		e := ele.Value.(*arcEntry)
+		e.value, e.expire = value, expire
+		c.t2.MoveToFront(ele)
+		return true, nil
+	}
+
+	// Case II: ghost hit in B1 -> grow p, favoring recency.
+	if ele, ok := c.b1m[key]; ok {
+		delta := 1
+		if c.b1.Len() > 0 && c.b2.Len() > c.b1.Len() {
+			delta = c.b2.Len() / c.b1.Len()
+		}
+		c.p = min(c.size, c.p+delta)
+		c.replace(false)
+		c.b1.Remove(ele)
+		delete(c.b1m, key)
+		e := &arcEntry{key: key, value: value, expire: expire}
+		c.t2m[key] = c.t2.PushFront(e)
+		return true, nil
+	}
+
+	// Case III: ghost hit in B2 -> shrink p, favoring frequency.
+	if ele, ok := c.b2m[key]; ok {
+		delta := 1
+		if c.b2.Len() > 0 && c.b1.Len() > c.b2.Len() {
+			delta = c.b1.Len() / c.b2.Len()
+		}
+		c.p = max(0, c.p-delta)
+		c.replace(true)
+		c.b2.Remove(ele)
+		delete(c.b2m, key)
+		e := &arcEntry{key: key, value: value, expire: expire}
+		c.t2m[key] = c.t2.PushFront(e)
+		return true, nil
+	}
+
+	// Case IV: brand new key, not present anywhere in the directory.
+	if c.t1.Len()+c.b1.Len() == c.size {
+		if c.t1.Len() < c.size {
+			c.dropGhostBack(c.b1, c.b1m)
+			c.replace(false)
+		} else {
+			c.dropBack(c.t1, c.t1m)
+		}
+	} else if c.t1.Len()+c.b1.Len() < c.size &&
+		c.t1.Len()+c.t2.Len()+c.b1.Len()+c.b2.Len() >= c.size {
+		if c.t1.Len()+c.t2.Len()+c.b1.Len()+c.b2.Len() >= 2*c.size {
+			c.dropGhostBack(c.b2, c.b2m)
+		}
+		c.replace(false)
+	}
+	e := &arcEntry{key: key, value: value, expire: expire}
+	c.t1m[key] = c.t1.PushFront(e)
+	return true, nil
+}
+
+// Get looks up a key's value from the cache. Any hit promotes the entry
+// to T2, since seeing it again is itself a frequency signal.
+func (c *ARCCache) Get(key Key) (value interface{}, ok bool) {
+	c.Lock()
+	defer c.Unlock()
+	if ele, hit := c.t1m[key]; hit {
+		e := ele.Value.(*arcEntry)
+		if e.isExpired() {
+			c.removeElement(c.t1, c.t1m, ele)
+			return
+		}
+		c.t1.Remove(ele)
+		delete(c.t1m, key)
+		c.t2m[key] = c.t2.PushFront(e)
+		return e.value, true
+	}
+	if ele, hit := c.t2m[key]; hit {
+		e := ele.Value.(*arcEntry)
+		if e.isExpired() {
+			c.removeElement(c.t2, c.t2m, ele)
+			return
+		}
+		c.t2.MoveToFront(ele)
+		return e.value, true
+	}
+	return
+}
+
+// Remove removes the provided key from the cache, wherever it currently
+// lives (T1, T2, B1 or B2).
+func (c *ARCCache) Remove(key Key) {
+	c.Lock()
+	defer c.Unlock()
+	if ele, ok := c.t1m[key]; ok {
+		c.removeElement(c.t1, c.t1m, ele)
+		return
+	}
+	if ele, ok := c.t2m[key]; ok {
+		c.removeElement(c.t2, c.t2m, ele)
+		return
+	}
+	if ele, ok := c.b1m[key]; ok {
+		c.b1.Remove(ele)
+		delete(c.b1m, key)
+		return
+	}
+	if ele, ok := c.b2m[key]; ok {
+		c.b2.Remove(ele)
+		delete(c.b2m, key)
+	}
+}
+
+// replace implements ARC's REPLACE(x, p): it evicts the LRU entry from
+// whichever of T1/T2 is currently over its adaptive share, demoting it
+// to the matching ghost list. xInB2 is true while handling a B2 ghost
+// hit, which per the algorithm biases the choice towards evicting T1.
+func (c *ARCCache) replace(xInB2 bool) {
+	t1Len := c.t1.Len()
+	switch {
+	case t1Len > 0 && (t1Len > c.p || (xInB2 && t1Len == c.p)):
+		c.moveBackToGhost(c.t1, c.t1m, c.b1, c.b1m)
+	case c.t2.Len() > 0:
+		c.moveBackToGhost(c.t2, c.t2m, c.b2, c.b2m)
+	case t1Len > 0:
+		c.moveBackToGhost(c.t1, c.t1m, c.b1, c.b1m)
+	}
+}
+
+// moveBackToGhost evicts the LRU entry of lst, fires OnEvicted (the
+// value is being discarded) and remembers the bare key in the matching
+// ghost list.
+func (c *ARCCache) moveBackToGhost(lst *list.List, m map[Key]*list.Element, ghostList *list.List, ghostMap map[Key]*list.Element) {
+	ele := lst.Back()
+	if ele == nil {
+		return
+	}
+	e := c.removeElement(lst, m, ele)
+	ghostMap[e.key] = ghostList.PushFront(e.key)
+}
+
+// dropBack evicts the LRU entry of lst outright, with no ghost entry.
+func (c *ARCCache) dropBack(lst *list.List, m map[Key]*list.Element) {
+	ele := lst.Back()
+	if ele == nil {
+		return
+	}
+	c.removeElement(lst, m, ele)
+}
+
+// dropGhostBack discards the LRU key of a ghost list; ghost lists hold
+// no value, so this never fires OnEvicted.
+func (c *ARCCache) dropGhostBack(ghostList *list.List, ghostMap map[Key]*list.Element) {
+	ele := ghostList.Back()
+	if ele == nil {
+		return
+	}
+	ghostList.Remove(ele)
+	delete(ghostMap, ele.Value.(Key))
+}
+
+func (c *ARCCache) removeElement(lst *list.List, m map[Key]*list.Element, ele *list.Element) *arcEntry {
+	lst.Remove(ele)
+	e := ele.Value.(*arcEntry)
+	delete(m, e.key)
+	if c.OnEvicted != nil {
+		c.OnEvicted(e.key, e.value)
+	}
+	return e
+}
+
+// Len returns the number of items currently cached (T1 + T2; the ghost
+// lists are bookkeeping, not live data).
+func (c *ARCCache) Len() int {
+	c.RLock()
+	defer c.RUnlock()
+	return c.t1.Len() + c.t2.Len()
+}
+
+// Keys return all the keys in cache, T1 before T2.
+func (c *ARCCache) Keys() []interface{} {
+	c.Lock()
+	defer c.Unlock()
+	keys := make([]interface{}, 0, c.t1.Len()+c.t2.Len())
+	for _, pair := range []struct {
+		lst *list.List
+		m   map[Key]*list.Element
+	}{{c.t1, c.t1m}, {c.t2, c.t2m}} {
+		for ele := pair.lst.Front(); ele != nil; {
+			next := ele.Next()
+			e := ele.Value.(*arcEntry)
+			if e.isExpired() {
+				c.removeElement(pair.lst, pair.m, ele)
+			} else {
+				keys = append(keys, e.key)
+			}
+			ele = next
+		}
+	}
+	return keys
+}
+
+// Values return all the values in cache, T1 before T2.
+func (c *ARCCache) Values() []interface{} {
+	c.Lock()
+	defer c.Unlock()
+	values := make([]interface{}, 0, c.t1.Len()+c.t2.Len())
+	for _, pair := range []struct {
+		lst *list.List
+		m   map[Key]*list.Element
+	}{{c.t1, c.t1m}, {c.t2, c.t2m}} {
+		for ele := pair.lst.Front(); ele != nil; {
+			next := ele.Next()
+			e := ele.Value.(*arcEntry)
+			if e.isExpired() {
+				c.removeElement(pair.lst, pair.m, ele)
+			} else {
+				values = append(values, e.value)
+			}
+			ele = next
+		}
+	}
+	return values
+}
+
+// Flush remove all the keys in cache, including both ghost lists.
+func (c *ARCCache) Flush() {
+	c.Lock()
+	defer c.Unlock()
+	c.t1, c.t2, c.b1, c.b2 = list.New(), list.New(), list.New(), list.New()
+	c.t1m = make(map[Key]*list.Element)
+	c.t2m = make(map[Key]*list.Element)
+	c.b1m = make(map[Key]*list.Element)
+	c.b2m = make(map[Key]*list.Element)
+	c.p = 0
+}
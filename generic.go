@@ -0,0 +1,306 @@
+package lru
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+)
+
+// CacheG is an LRU cache keyed by a comparable type K holding values of
+// type V. It is the generic core that the untyped Cache is built on top
+// of; use it directly when K and V are known at compile time so Get,
+// Keys and Values don't require a type assertion.
+type CacheG[K comparable, V any] struct {
+	sync.RWMutex
+	// MaxEntries is the maximum number of cache entries before
+	// an item is evicted. Zero means no limit.
+	MaxEntries int
+	// OnEvicted optionally specifics a callback function to be
+	// executed when an entry is purged from the cache.
+	OnEvicted func(key K, value V)
+	// OnEvictedWithReason is set via WithEvictReason. When present it is
+	// called instead of OnEvicted, additionally reporting why the entry
+	// was removed.
+	OnEvictedWithReason func(key K, value V, reason EvictReason)
+
+	ll     *list.List
+	cache  map[K]*list.Element
+	expire int64 // default TTL in seconds, applied by Set; 0 means no expiry
+
+	updateAgeOnGet bool // WithUpdateAgeOnGet: refresh expiry on hit
+	allowStale     bool // WithStale: let GetWithStale observe expired entries
+
+	reaperOnce sync.Once // guards starting cleanExpired exactly once
+}
+
+type entryG[K comparable, V any] struct {
+	key    K
+	value  V
+	expire int64 // absolute unix expiry, 0 means no expiry
+	ttl    int64 // seconds this entry was set with, for WithUpdateAgeOnGet
+}
+
+// EvictReason describes why an entry was removed from the cache, reported
+// to a callback installed with WithEvictReason.
+type EvictReason int
+
+const (
+	// EvictReasonCapacity means the entry was evicted to keep the cache
+	// within MaxEntries.
+	EvictReasonCapacity EvictReason = iota
+	// EvictReasonRemoved means the entry was removed explicitly via
+	// Remove.
+	EvictReasonRemoved
+	// EvictReasonExpired means the entry's TTL elapsed.
+	EvictReasonExpired
+)
+
+// NewCacheG creates a new CacheG.
+// If maxEntries is zero, the cache has no limit and it's assumed
+// that eviction is done by the caller.
+func NewCacheG[K comparable, V any](maxEntries int, expired int64) *CacheG[K, V] {
+	c := &CacheG[K, V]{
+		MaxEntries: maxEntries,
+		ll:         list.New(),
+		cache:      make(map[K]*list.Element),
+		expire:     expired,
+	}
+	if c.expire > 0 {
+		c.startReaper()
+	}
+	return c
+}
+
+// defaultCleanInterval is how often cleanExpired sweeps the cache when it
+// has no cache-wide default TTL to derive an interval from, e.g. a cache
+// populated entirely through SetWithExpire.
+const defaultCleanInterval = time.Second
+
+// startReaper launches cleanExpired at most once per cache. It is called
+// eagerly when a default TTL is configured and lazily from SetWithExpire
+// the first time a caller attaches a per-entry TTL, so a cache that never
+// uses expiry doesn't leak a goroutine for its entire lifetime.
+func (c *CacheG[K, V]) startReaper() {
+	c.reaperOnce.Do(func() {
+		go c.cleanExpired()
+	})
+}
+
+// Check whether entry is expired or not
+func (e *entryG[K, V]) isExpired() bool {
+	if e.expire == 0 { // entry without expire
+		return false
+	}
+	if e.expire >= time.Now().Unix() {
+		return false
+	}
+	return true
+}
+
+// cleans expired entries performing minimal checks. It walks the whole
+// list each tick, since per-entry TTLs (SetWithExpire) mean entries aren't
+// ordered by expiry, only by recency. With WithStale, expired entries are
+// left in place for GetWithStale to serve; they are cleared only by an
+// explicit Remove, by capacity eviction, or lazily by Get, Keys or Values.
+func (c *CacheG[K, V]) cleanExpired() {
+	interval := time.Duration(c.expire) * time.Second
+	if interval <= 0 {
+		interval = defaultCleanInterval
+	}
+	for {
+		c.Lock()
+		if !c.allowStale {
+			for ele := c.ll.Back(); ele != nil; {
+				prev := ele.Prev()
+				if ele.Value.(*entryG[K, V]).isExpired() {
+					c.removeElement(ele, EvictReasonExpired)
+				}
+				ele = prev
+			}
+		}
+		c.Unlock()
+		time.Sleep(interval)
+	}
+}
+
+// Set a value to the cache using the cache's default TTL.
+// Key and value is required.
+func (c *CacheG[K, V]) Set(key K, value V) (bool, error) {
+	return c.SetWithExpire(key, value, time.Duration(c.expire)*time.Second)
+}
+
+// SetWithExpire sets a value to the cache with a per-entry TTL, overriding
+// the cache's default TTL for this entry. The ttl is truncated to whole
+// seconds; a ttl under one second (including zero or less) means the
+// entry never expires.
+func (c *CacheG[K, V]) SetWithExpire(key K, value V, ttl time.Duration) (bool, error) {
+	if c.cache == nil {
+		return false, errors.New("cache is not initialized")
+	}
+	//
+	ttlSeconds := int64(ttl.Seconds())
+	if ttlSeconds > 0 {
+		c.startReaper()
+	}
+	c.Lock()
+	var expire int64
+	if ttlSeconds > 0 {
+		expire = time.Now().Unix() + ttlSeconds
+	}
+	if ee, ok := c.cache[key]; ok {
+		c.ll.MoveToFront(ee)
+		e := ee.Value.(*entryG[K, V])
+		e.value = value
+		e.expire = expire
+		e.ttl = ttlSeconds
+		c.Unlock()
+		return true, nil
+	}
+	ele := c.ll.PushFront(&entryG[K, V]{key: key, value: value, expire: expire, ttl: ttlSeconds})
+	c.cache[key] = ele
+	if c.MaxEntries != 0 && c.ll.Len() > c.MaxEntries {
+		c.removeOldest()
+	}
+	c.Unlock()
+	return true, nil
+}
+
+// Get looks up a key's value from the cache.
+func (c *CacheG[K, V]) Get(key K) (value V, ok bool) {
+	c.Lock()
+	defer c.Unlock()
+	if c.cache == nil {
+		return
+	}
+	if ele, hit := c.cache[key]; hit {
+		e := ele.Value.(*entryG[K, V])
+		if e.isExpired() {
+			// delete expired elem
+			c.removeElement(ele, EvictReasonExpired)
+			return
+		}
+		if c.updateAgeOnGet && e.ttl > 0 {
+			e.expire = time.Now().Unix() + e.ttl
+		}
+		c.ll.MoveToFront(ele)
+		return e.value, true
+	}
+	return
+}
+
+// GetWithStale looks up a key's value like Get, but when the cache was
+// built with WithStale it also returns expired-but-still-present entries:
+// ok is false and stale is true, letting the caller serve the stale value
+// while it revalidates. Without WithStale, stale is always false and an
+// expired entry behaves exactly like Get: it is removed and ok is false.
+func (c *CacheG[K, V]) GetWithStale(key K) (value V, ok bool, stale bool) {
+	c.Lock()
+	defer c.Unlock()
+	if c.cache == nil {
+		return
+	}
+	ele, hit := c.cache[key]
+	if !hit {
+		return
+	}
+	e := ele.Value.(*entryG[K, V])
+	if e.isExpired() {
+		if c.allowStale {
+			return e.value, false, true
+		}
+		c.removeElement(ele, EvictReasonExpired)
+		return
+	}
+	if c.updateAgeOnGet && e.ttl > 0 {
+		e.expire = time.Now().Unix() + e.ttl
+	}
+	c.ll.MoveToFront(ele)
+	return e.value, true, false
+}
+
+// Remove removes the provided key from the cache.
+func (c *CacheG[K, V]) Remove(key K) {
+	c.Lock()
+	defer c.Unlock()
+	if c.cache == nil {
+		return
+	}
+	if ele, hit := c.cache[key]; hit {
+		c.removeElement(ele, EvictReasonRemoved)
+	}
+}
+
+// removeOldest removes the oldest item from the cache.
+func (c *CacheG[K, V]) removeOldest() {
+	if c.cache == nil {
+		return
+	}
+	ele := c.ll.Back()
+	if ele != nil {
+		c.removeElement(ele, EvictReasonCapacity)
+	}
+}
+
+func (c *CacheG[K, V]) removeElement(e *list.Element, reason EvictReason) {
+	c.ll.Remove(e)
+	kv := e.Value.(*entryG[K, V])
+	delete(c.cache, kv.key)
+	if c.OnEvictedWithReason != nil {
+		c.OnEvictedWithReason(kv.key, kv.value, reason)
+	} else if c.OnEvicted != nil {
+		c.OnEvicted(kv.key, kv.value)
+	}
+}
+
+// Len returns the number of items in the cache.
+func (c *CacheG[K, V]) Len() int {
+	c.RLock()
+	defer c.RUnlock()
+	if c.cache == nil {
+		return 0
+	}
+	return c.ll.Len()
+}
+
+// Keys return all the keys in cache
+func (c *CacheG[K, V]) Keys() []K {
+	c.Lock()
+	defer c.Unlock()
+	keys := make([]K, 0, c.ll.Len())
+	for ele := c.ll.Front(); ele != nil; {
+		next := ele.Next()
+		if ele.Value.(*entryG[K, V]).isExpired() {
+			c.removeElement(ele, EvictReasonExpired)
+		} else {
+			keys = append(keys, ele.Value.(*entryG[K, V]).key)
+		}
+		ele = next
+	}
+	return keys
+}
+
+// Values return all the value in cache
+func (c *CacheG[K, V]) Values() []V {
+	c.Lock()
+	defer c.Unlock()
+	values := make([]V, 0, c.ll.Len())
+	for ele := c.ll.Front(); ele != nil; {
+		next := ele.Next()
+		if ele.Value.(*entryG[K, V]).isExpired() {
+			c.removeElement(ele, EvictReasonExpired)
+		} else {
+			values = append(values, ele.Value.(*entryG[K, V]).value)
+		}
+		ele = next
+	}
+	return values
+}
+
+// Flush remove all the keys in cache
+func (c *CacheG[K, V]) Flush() {
+	c.Lock()
+	defer c.Unlock()
+	c.ll = list.New()
+	c.cache = make(map[K]*list.Element)
+}
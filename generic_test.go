@@ -0,0 +1,21 @@
+package lru
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGetG(t *testing.T) {
+	lru := NewCacheG[int, string](10, 0)
+	for i := 0; i < 10; i++ {
+		lru.Set(i, fmt.Sprint(i)+"-"+fmt.Sprint(i))
+	}
+	v, ok := lru.Get(1)
+	if !ok || v != "1-1" {
+		t.Fatalf("Get(1) = %q, %v; want \"1-1\", true", v, ok)
+	}
+	fmt.Println(lru.Keys())
+	fmt.Println(lru.Values())
+	lru.Flush()
+	fmt.Println(lru.Len())
+}
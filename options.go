@@ -0,0 +1,72 @@
+package lru
+
+import (
+	"container/list"
+	"time"
+)
+
+// Option configures a CacheG built with NewCacheWithOptions.
+type Option[K comparable, V any] func(*CacheG[K, V])
+
+// NewCacheWithOptions creates a new CacheG configured by opts, e.g.
+//
+//	c := NewCacheWithOptions[string, *Record](
+//		WithMaxEntries[string, *Record](1000),
+//		WithDefaultTTL[string, *Record](5*time.Minute),
+//		WithStale[string, *Record](),
+//	)
+func NewCacheWithOptions[K comparable, V any](opts ...Option[K, V]) *CacheG[K, V] {
+	c := &CacheG[K, V]{
+		ll:    list.New(),
+		cache: make(map[K]*list.Element),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.expire > 0 {
+		c.startReaper()
+	}
+	return c
+}
+
+// WithMaxEntries sets the maximum number of cache entries before an item
+// is evicted. Equivalent to setting MaxEntries directly.
+func WithMaxEntries[K comparable, V any](n int) Option[K, V] {
+	return func(c *CacheG[K, V]) {
+		c.MaxEntries = n
+	}
+}
+
+// WithDefaultTTL sets the TTL applied to entries written with Set (as
+// opposed to SetWithExpire, which overrides it per entry).
+func WithDefaultTTL[K comparable, V any](d time.Duration) Option[K, V] {
+	return func(c *CacheG[K, V]) {
+		c.expire = int64(d.Seconds())
+	}
+}
+
+// WithUpdateAgeOnGet refreshes an entry's expiration every time it is hit
+// by Get or GetWithStale, instead of only at write time.
+func WithUpdateAgeOnGet[K comparable, V any]() Option[K, V] {
+	return func(c *CacheG[K, V]) {
+		c.updateAgeOnGet = true
+	}
+}
+
+// WithStale lets GetWithStale return expired-but-still-present entries
+// (ok=false, stale=true) instead of evicting them on the first miss, so
+// callers can serve stale data while they revalidate.
+func WithStale[K comparable, V any]() Option[K, V] {
+	return func(c *CacheG[K, V]) {
+		c.allowStale = true
+	}
+}
+
+// WithEvictReason installs a callback that is told why an entry was
+// evicted (capacity, explicit Remove, or expiration). It takes priority
+// over OnEvicted when both are set.
+func WithEvictReason[K comparable, V any](fn func(key K, value V, reason EvictReason)) Option[K, V] {
+	return func(c *CacheG[K, V]) {
+		c.OnEvictedWithReason = fn
+	}
+}
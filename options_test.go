@@ -0,0 +1,65 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetWithExpire(t *testing.T) {
+	c := NewCacheG[string, int](0, 0)
+	c.SetWithExpire("short", 1, 1*time.Second)
+	c.Set("forever", 2)
+	time.Sleep(2 * time.Second)
+	if _, ok := c.Get("short"); ok {
+		t.Fatalf("short should have expired")
+	}
+	if v, ok := c.Get("forever"); !ok || v != 2 {
+		t.Fatalf("forever = %v, %v; want 2, true", v, ok)
+	}
+}
+
+func TestWithStale(t *testing.T) {
+	c := NewCacheWithOptions[string, int](WithStale[string, int]())
+	c.SetWithExpire("k", 42, 1*time.Second)
+	time.Sleep(2 * time.Second)
+
+	if v, ok, stale := c.GetWithStale("k"); ok || !stale || v != 42 {
+		t.Fatalf("GetWithStale = %v, %v, %v; want 42, false, true", v, ok, stale)
+	}
+	if v, ok := c.Get("k"); ok {
+		t.Fatalf("Get should not serve stale values, got %v, %v", v, ok)
+	}
+}
+
+func TestWithUpdateAgeOnGet(t *testing.T) {
+	c := NewCacheWithOptions[string, int](
+		WithDefaultTTL[string, int](2*time.Second),
+		WithUpdateAgeOnGet[string, int](),
+	)
+	c.Set("k", 1)
+	time.Sleep(1 * time.Second)
+	if _, ok := c.Get("k"); !ok {
+		t.Fatalf("k should still be alive")
+	}
+	time.Sleep(1500 * time.Millisecond)
+	if _, ok := c.Get("k"); !ok {
+		t.Fatalf("k should have been kept alive by the refreshed TTL")
+	}
+}
+
+func TestWithEvictReason(t *testing.T) {
+	var reasons []EvictReason
+	c := NewCacheWithOptions[string, int](
+		WithMaxEntries[string, int](1),
+		WithEvictReason(func(key string, value int, reason EvictReason) {
+			reasons = append(reasons, reason)
+		}),
+	)
+	c.Set("a", 1)
+	c.Set("b", 2) // evicts a for capacity
+	c.Remove("b") // explicit removal
+
+	if len(reasons) != 2 || reasons[0] != EvictReasonCapacity || reasons[1] != EvictReasonRemoved {
+		t.Fatalf("reasons = %v; want [capacity removed]", reasons)
+	}
+}
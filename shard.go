@@ -0,0 +1,175 @@
+package lru
+
+import (
+	"fmt"
+	"hash/fnv"
+	"runtime"
+)
+
+// ShardedCache splits entries across several independent Cache shards.
+// Cache.Get takes a write lock to run MoveToFront, so a single Cache
+// serializes every goroutine on one mutex; spreading keys across shards
+// lets unrelated keys proceed concurrently.
+//
+// The public API mirrors Cache: Len, Keys and Values work across all
+// shards, and MaxEntries passed to NewShardedCache is the aggregate
+// limit, divided evenly across shards. When MaxEntries is positive but
+// smaller than the shard count, every shard still gets a cap of one, so
+// the cache can hold up to one entry per shard.
+type ShardedCache struct {
+	// OnEvicted optionally specifics a callback function to be executed
+	// when an entry is purged from any shard.
+	OnEvicted func(key Key, value interface{})
+
+	shards []*Cache
+	mask   uint64
+}
+
+// NewShardedCache creates a ShardedCache with the given aggregate
+// MaxEntries and per-entry expire (see NewCache), split across n shards.
+// n is rounded up to the next power of two so keys can be routed with a
+// mask instead of a modulo; if n is zero, runtime.GOMAXPROCS(0) is used.
+func NewShardedCache(maxEntries int, expired int64, n int) *ShardedCache {
+	if n <= 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	n = nextPowerOfTwo(n)
+
+	c := &ShardedCache{
+		shards: make([]*Cache, n),
+		mask:   uint64(n - 1),
+	}
+	for i := range c.shards {
+		shardMax := maxEntries / n
+		if maxEntries > 0 {
+			if i < maxEntries%n {
+				shardMax++
+			}
+			if shardMax == 0 {
+				// maxEntries < n: every shard still needs a cap, or the
+				// aggregate limit silently becomes "unlimited" (0).
+				shardMax = 1
+			}
+		}
+		shard := NewCache(shardMax, expired)
+		shard.OnEvicted = func(key Key, value interface{}) {
+			if c.OnEvicted != nil {
+				c.OnEvicted(key, value)
+			}
+		}
+		c.shards[i] = shard
+	}
+	return c
+}
+
+func nextPowerOfTwo(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+// shardHash picks a shard for key. string and []byte use fnv-1a;
+// common integer kinds are used directly without reflection; any other
+// comparable type falls back to hashing its fmt.Sprint representation.
+func shardHash(key Key) uint64 {
+	switch k := key.(type) {
+	case string:
+		return fnv1a(k)
+	case []byte:
+		return fnv1aBytes(k)
+	case int:
+		return uint64(k)
+	case int8:
+		return uint64(k)
+	case int16:
+		return uint64(k)
+	case int32:
+		return uint64(k)
+	case int64:
+		return uint64(k)
+	case uint:
+		return uint64(k)
+	case uint8:
+		return uint64(k)
+	case uint16:
+		return uint64(k)
+	case uint32:
+		return uint64(k)
+	case uint64:
+		return k
+	default:
+		return fnv1a(fmt.Sprint(key))
+	}
+}
+
+func fnv1a(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+func fnv1aBytes(b []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(b)
+	return h.Sum64()
+}
+
+func (c *ShardedCache) shardFor(key Key) *Cache {
+	return c.shards[shardHash(key)&c.mask]
+}
+
+// Set a value to the cache. Key and value is required.
+func (c *ShardedCache) Set(key Key, value interface{}) (bool, error) {
+	return c.shardFor(key).Set(key, value)
+}
+
+// Get looks up a key's value from the cache.
+func (c *ShardedCache) Get(key Key) (value interface{}, ok bool) {
+	return c.shardFor(key).Get(key)
+}
+
+// Remove removes the provided key from the cache.
+func (c *ShardedCache) Remove(key Key) {
+	c.shardFor(key).Remove(key)
+}
+
+// Len returns the number of items across all shards.
+func (c *ShardedCache) Len() int {
+	n := 0
+	for _, s := range c.shards {
+		n += s.Len()
+	}
+	return n
+}
+
+// Keys return all the keys across all shards, in no particular order.
+func (c *ShardedCache) Keys() []interface{} {
+	keys := make([]interface{}, 0, c.Len())
+	for _, s := range c.shards {
+		for _, k := range s.Keys() {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// Values return all the values across all shards, in no particular order.
+func (c *ShardedCache) Values() []interface{} {
+	values := make([]interface{}, 0, c.Len())
+	for _, s := range c.shards {
+		values = append(values, s.Values()...)
+	}
+	return values
+}
+
+// Flush remove all the keys in every shard.
+func (c *ShardedCache) Flush() {
+	for _, s := range c.shards {
+		s.Flush()
+	}
+}
@@ -0,0 +1,66 @@
+package lru
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestShardedCache(t *testing.T) {
+	c := NewShardedCache(400, 0, 4)
+	var evicted []interface{}
+	c.OnEvicted = func(key Key, value interface{}) {
+		evicted = append(evicted, key)
+	}
+	for i := 0; i < 100; i++ {
+		c.Set(i, fmt.Sprint(i))
+	}
+	if v, ok := c.Get(42); !ok || v != "42" {
+		t.Fatalf("Get(42) = %v, %v; want \"42\", true", v, ok)
+	}
+	if n := c.Len(); n != 100 {
+		t.Fatalf("Len() = %d; want 100", n)
+	}
+	if len(c.Keys()) != 100 || len(c.Values()) != 100 {
+		t.Fatalf("Keys/Values did not cover all shards")
+	}
+	c.Remove(42)
+	if _, ok := c.Get(42); ok {
+		t.Fatalf("42 should have been removed")
+	}
+	if len(evicted) != 1 || evicted[0] != 42 {
+		t.Fatalf("evicted = %v; want [42]", evicted)
+	}
+	c.Flush()
+	if c.Len() != 0 {
+		t.Fatalf("Len() after Flush = %d; want 0", c.Len())
+	}
+}
+
+func benchmarkCacheGetParallel(b *testing.B, get func(int) (interface{}, bool), set func(int)) {
+	const universe = 10000
+	for i := 0; i < universe; i++ {
+		set(i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			get(i % universe)
+			i++
+		}
+	})
+}
+
+func BenchmarkCacheGetParallel(b *testing.B) {
+	c := NewCache(10000, 0)
+	benchmarkCacheGetParallel(b,
+		func(k int) (interface{}, bool) { return c.Get(k) },
+		func(k int) { c.Set(k, k) })
+}
+
+func BenchmarkShardedCacheGetParallel(b *testing.B) {
+	c := NewShardedCache(10000, 0, 0)
+	benchmarkCacheGetParallel(b,
+		func(k int) (interface{}, bool) { return c.Get(k) },
+		func(k int) { c.Set(k, k) })
+}
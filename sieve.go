@@ -0,0 +1,232 @@
+package lru
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+)
+
+// SieveCache is a cache using the SIEVE eviction algorithm. SIEVE tends to
+// beat classic LRU on miss ratio for web/DNS-style workloads while being
+// simpler than ARC or 2Q: it needs only one extra pointer (hand) and one
+// extra bool per entry (visited), and a hit never moves an entry in the
+// list.
+type SieveCache struct {
+	sync.RWMutex
+	// MaxEntries is the maximum number of cache entries before
+	// an item is evicted. Zero means no limit.
+	MaxEntries int
+	// OnEvicted optionally specifics a callback function to be
+	// executed when an entry is purged from the cache.
+	OnEvicted func(key Key, value interface{})
+
+	ll     *list.List
+	cache  map[interface{}]*list.Element
+	hand   *list.Element
+	expire int64
+}
+
+type sieveEntry struct {
+	key     Key
+	value   interface{}
+	expire  int64
+	visited bool
+}
+
+// NewSieveCache creates a new SieveCache.
+// If maxEntries is zero, the cache has no limit and it's assumed
+// that eviction is done by the caller.
+func NewSieveCache(maxEntries int, expired int64) *SieveCache {
+	c := &SieveCache{
+		MaxEntries: maxEntries,
+		ll:         list.New(),
+		cache:      make(map[interface{}]*list.Element),
+		expire:     expired,
+	}
+	if c.expire > 0 {
+		go c.cleanExpired()
+	}
+	return c
+}
+
+// Check whether entry is expired or not
+func (e *sieveEntry) isExpired() bool {
+	if e.expire == 0 { // entry without expire
+		return false
+	}
+	if e.expire >= time.Now().Unix() {
+		return false
+	}
+	return true
+}
+
+// cleans expired entries performing minimal checks
+func (c *SieveCache) cleanExpired() {
+	for {
+		c.Lock()
+		if ele := c.ll.Back(); ele != nil && ele.Value.(*sieveEntry).isExpired() {
+			c.removeElement(ele)
+		}
+		c.Unlock()
+		time.Sleep(time.Duration(c.expire) * time.Second)
+	}
+}
+
+// Set a value to the cache.
+// Key and value is required.
+func (c *SieveCache) Set(key Key, value interface{}) (bool, error) {
+	if c.cache == nil {
+		return false, errors.New("cache is not initialized")
+	}
+	c.Lock()
+	var expire int64
+	if c.expire > 0 {
+		expire = time.Now().Unix() + c.expire
+	}
+	if ee, ok := c.cache[key]; ok {
+		ee.Value.(*sieveEntry).value = value
+		ee.Value.(*sieveEntry).expire = expire
+		c.Unlock()
+		return true, nil
+	}
+	if c.MaxEntries != 0 && c.ll.Len() >= c.MaxEntries {
+		c.evict()
+	}
+	ele := c.ll.PushFront(&sieveEntry{key: key, value: value, expire: expire})
+	c.cache[key] = ele
+	c.Unlock()
+	return true, nil
+}
+
+// Get looks up a key's value from the cache. Unlike the LRU Cache, a hit
+// only marks the entry as visited; it does not move the entry in the
+// list.
+func (c *SieveCache) Get(key Key) (value interface{}, ok bool) {
+	c.Lock()
+	defer c.Unlock()
+	if c.cache == nil {
+		return
+	}
+	if ele, hit := c.cache[key]; hit {
+		if ele.Value.(*sieveEntry).isExpired() {
+			c.removeElement(ele)
+			return
+		}
+		ele.Value.(*sieveEntry).visited = true
+		return ele.Value.(*sieveEntry).value, true
+	}
+	return
+}
+
+// Remove removes the provided key from the cache.
+func (c *SieveCache) Remove(key Key) {
+	c.Lock()
+	defer c.Unlock()
+	if c.cache == nil {
+		return
+	}
+	if ele, hit := c.cache[key]; hit {
+		c.removeElement(ele)
+	}
+}
+
+// evict runs the SIEVE eviction scan starting from hand (or the list
+// tail if hand is nil), walking backwards towards the head. Entries
+// marked visited are spared once, have their bit cleared, and the scan
+// continues; the first unvisited entry found is evicted.
+func (c *SieveCache) evict() {
+	if c.cache == nil {
+		return
+	}
+	ele := c.hand
+	if ele == nil {
+		ele = c.ll.Back()
+	}
+	for ele != nil {
+		e := ele.Value.(*sieveEntry)
+		if e.visited {
+			e.visited = false
+			prev := ele.Prev()
+			if prev == nil {
+				prev = c.ll.Back()
+			}
+			ele = prev
+			continue
+		}
+		break
+	}
+	if ele == nil {
+		return
+	}
+	c.hand = ele.Prev()
+	if c.hand == nil {
+		c.hand = c.ll.Back()
+	}
+	c.removeElement(ele)
+}
+
+func (c *SieveCache) removeElement(e *list.Element) {
+	if c.hand == e {
+		c.hand = e.Prev()
+	}
+	c.ll.Remove(e)
+	kv := e.Value.(*sieveEntry)
+	delete(c.cache, kv.key)
+	if c.OnEvicted != nil {
+		c.OnEvicted(kv.key, kv.value)
+	}
+}
+
+// Len returns the number of items in the cache.
+func (c *SieveCache) Len() int {
+	c.RLock()
+	defer c.RUnlock()
+	if c.cache == nil {
+		return 0
+	}
+	return c.ll.Len()
+}
+
+// Keys return all the keys in cache
+func (c *SieveCache) Keys() []interface{} {
+	c.Lock()
+	defer c.Unlock()
+	keys := make([]interface{}, 0, c.ll.Len())
+	for ele := c.ll.Front(); ele != nil; {
+		next := ele.Next()
+		if ele.Value.(*sieveEntry).isExpired() {
+			c.removeElement(ele)
+		} else {
+			keys = append(keys, ele.Value.(*sieveEntry).key)
+		}
+		ele = next
+	}
+	return keys
+}
+
+// Values return all the value in cache
+func (c *SieveCache) Values() []interface{} {
+	c.Lock()
+	defer c.Unlock()
+	values := make([]interface{}, 0, c.ll.Len())
+	for ele := c.ll.Front(); ele != nil; {
+		next := ele.Next()
+		if ele.Value.(*sieveEntry).isExpired() {
+			c.removeElement(ele)
+		} else {
+			values = append(values, ele.Value.(*sieveEntry).value)
+		}
+		ele = next
+	}
+	return values
+}
+
+// Flush remove all the keys in cache
+func (c *SieveCache) Flush() {
+	c.Lock()
+	defer c.Unlock()
+	c.ll = list.New()
+	c.cache = make(map[interface{}]*list.Element)
+	c.hand = nil
+}
@@ -0,0 +1,64 @@
+package lru
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestSieveGet(t *testing.T) {
+	c := NewSieveCache(2, 0)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("Get(a) = %v, %v; want 1, true", v, ok)
+	}
+	// a was visited, b was not; adding c must evict b, not a.
+	c.Set("c", 3)
+	if _, ok := c.Get("b"); ok {
+		t.Fatalf("b should have been evicted, a was visited more recently")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Fatalf("a should have survived eviction")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Fatalf("c should be present")
+	}
+}
+
+// zipfHitRatio runs n requests drawn from a Zipf distribution over
+// universe keys through get/set and returns the fraction that hit.
+func zipfHitRatio(universe, n int, get func(int) (interface{}, bool), set func(int)) float64 {
+	z := rand.NewZipf(rand.New(rand.NewSource(1)), 1.2, 1, uint64(universe-1))
+	hits := 0
+	for i := 0; i < n; i++ {
+		key := int(z.Uint64())
+		if _, ok := get(key); ok {
+			hits++
+		} else {
+			set(key)
+		}
+	}
+	return float64(hits) / float64(n)
+}
+
+func TestSieveVsLRUHitRate(t *testing.T) {
+	const universe = 1000
+	const capacity = 100
+	const requests = 20000
+
+	lru := NewCacheG[int, int](capacity, 0)
+	lruHit := zipfHitRatio(universe, requests,
+		func(k int) (interface{}, bool) { return lru.Get(k) },
+		func(k int) { lru.Set(k, k) })
+
+	sieve := NewSieveCache(capacity, 0)
+	sieveHit := zipfHitRatio(universe, requests,
+		func(k int) (interface{}, bool) { return sieve.Get(k) },
+		func(k int) { sieve.Set(k, k) })
+
+	fmt.Printf("LRU hit ratio: %.4f, SIEVE hit ratio: %.4f\n", lruHit, sieveHit)
+	if sieveHit < lruHit {
+		t.Fatalf("SIEVE hit ratio %.4f should be at least the LRU hit ratio %.4f on a Zipf workload", sieveHit, lruHit)
+	}
+}
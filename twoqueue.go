@@ -0,0 +1,274 @@
+package lru
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// TwoQueueCache is a scan-resistant cache implementing the 2Q algorithm.
+// Plain LRU is vulnerable to one-off scans evicting a hot working set;
+// 2Q protects against this by requiring a key to be seen twice before it
+// is treated as frequent. It offers the same OnEvicted callback and
+// cache-wide TTL as Cache, but with its own entry type and expiry sweep
+// rather than Cache's, and with three lists instead of one: recent,
+// frequent and a key-only ghost list of recent evictions.
+//
+//	recent:   25% of size, holds keys seen once
+//	frequent: 50% of size, holds keys seen at least twice
+//	ghost:    50% of size, remembers keys evicted from recent
+type TwoQueueCache struct {
+	sync.RWMutex
+	// OnEvicted optionally specifics a callback function to be executed
+	// when an entry's value is purged from the cache, whether evicted
+	// outright or demoted to the ghost list.
+	OnEvicted func(key Key, value interface{})
+
+	recentCap   int
+	frequentCap int
+	ghostCap    int
+
+	recent   *list.List // *tqEntry, keys seen once
+	frequent *list.List // *tqEntry, keys seen at least twice
+	ghost    *list.List // Key, recently evicted from recent
+
+	cache      map[Key]*list.Element // key -> node in recent or frequent
+	ghostIndex map[Key]*list.Element // key -> node in ghost
+
+	expire int64
+}
+
+type tqEntry struct {
+	key    Key
+	value  interface{}
+	expire int64
+	inFreq bool
+}
+
+// Check whether entry is expired or not
+func (e *tqEntry) isExpired() bool {
+	if e.expire == 0 {
+		return false
+	}
+	return e.expire < time.Now().Unix()
+}
+
+// NewTwoQueueCache creates a new TwoQueueCache from size: recent holds up
+// to size/4 entries and frequent up to size/2 (each at least 1), so at
+// most about 3/4 of size entries are live at once. If expired is zero,
+// entries never expire.
+func NewTwoQueueCache(size int, expired int64) *TwoQueueCache {
+	recentCap := max(1, size/4)
+	frequentCap := max(1, size/2)
+	ghostCap := max(1, size/2)
+	c := &TwoQueueCache{
+		recentCap:   recentCap,
+		frequentCap: frequentCap,
+		ghostCap:    ghostCap,
+		recent:      list.New(),
+		frequent:    list.New(),
+		ghost:       list.New(),
+		cache:       make(map[Key]*list.Element),
+		ghostIndex:  make(map[Key]*list.Element),
+		expire:      expired,
+	}
+	if c.expire > 0 {
+		go c.cleanExpired()
+	}
+	return c
+}
+
+// cleans expired entries performing minimal checks
+func (c *TwoQueueCache) cleanExpired() {
+	for {
+		c.Lock()
+		if e := c.recent.Back(); e != nil && e.Value.(*tqEntry).isExpired() {
+			c.removeReal(e)
+		} else if e := c.frequent.Back(); e != nil && e.Value.(*tqEntry).isExpired() {
+			c.removeReal(e)
+		}
+		c.Unlock()
+		time.Sleep(time.Duration(c.expire) * time.Second)
+	}
+}
+
+// Set a value to the cache. Key and value is required.
+func (c *TwoQueueCache) Set(key Key, value interface{}) (bool, error) {
+	c.Lock()
+	defer c.Unlock()
+	var expire int64
+	if c.expire > 0 {
+		expire = time.Now().Unix() + c.expire
+	}
+
+	if ele, ok := c.cache[key]; ok {
+		e := ele.Value.(*tqEntry)
+		e.value = value
+		e.expire = expire
+		if e.inFreq {
+			c.frequent.MoveToFront(ele)
+		}
+		return true, nil
+	}
+
+	if gele, ok := c.ghostIndex[key]; ok {
+		// Ghost hit: this key was popular enough to be remembered after
+		// eviction, so it skips recent and goes straight to frequent.
+		c.ghost.Remove(gele)
+		delete(c.ghostIndex, key)
+		e := &tqEntry{key: key, value: value, expire: expire, inFreq: true}
+		c.cache[key] = c.frequent.PushFront(e)
+		c.evictFrequentOverflow()
+		return true, nil
+	}
+
+	e := &tqEntry{key: key, value: value, expire: expire}
+	c.cache[key] = c.recent.PushFront(e)
+	c.evictRecentOverflow()
+	return true, nil
+}
+
+// Get looks up a key's value from the cache. A hit in recent promotes
+// the key to frequent; a hit in frequent just moves it to the front.
+func (c *TwoQueueCache) Get(key Key) (value interface{}, ok bool) {
+	c.Lock()
+	defer c.Unlock()
+	ele, hit := c.cache[key]
+	if !hit {
+		return
+	}
+	e := ele.Value.(*tqEntry)
+	if e.isExpired() {
+		c.removeReal(ele)
+		return
+	}
+	if !e.inFreq {
+		c.recent.Remove(ele)
+		e.inFreq = true
+		c.cache[key] = c.frequent.PushFront(e)
+		c.evictFrequentOverflow()
+	} else {
+		c.frequent.MoveToFront(ele)
+	}
+	return e.value, true
+}
+
+// Remove removes the provided key from the cache, wherever it currently
+// lives (recent, frequent or the ghost list).
+func (c *TwoQueueCache) Remove(key Key) {
+	c.Lock()
+	defer c.Unlock()
+	if ele, ok := c.cache[key]; ok {
+		c.removeReal(ele)
+		return
+	}
+	if gele, ok := c.ghostIndex[key]; ok {
+		c.ghost.Remove(gele)
+		delete(c.ghostIndex, key)
+	}
+}
+
+// evictRecentOverflow demotes the oldest recent entry to the ghost list
+// once recent exceeds its capacity, then trims the ghost list itself.
+func (c *TwoQueueCache) evictRecentOverflow() {
+	if c.recent.Len() <= c.recentCap {
+		return
+	}
+	ele := c.recent.Back()
+	e := ele.Value.(*tqEntry)
+	c.recent.Remove(ele)
+	delete(c.cache, e.key)
+	if c.OnEvicted != nil {
+		c.OnEvicted(e.key, e.value)
+	}
+	c.ghostIndex[e.key] = c.ghost.PushFront(e.key)
+	if c.ghost.Len() > c.ghostCap {
+		gele := c.ghost.Back()
+		c.ghost.Remove(gele)
+		delete(c.ghostIndex, gele.Value.(Key))
+	}
+}
+
+// evictFrequentOverflow evicts the oldest frequent entry outright once
+// frequent exceeds its capacity; frequent evictions have no second
+// chance via the ghost list.
+func (c *TwoQueueCache) evictFrequentOverflow() {
+	if c.frequent.Len() <= c.frequentCap {
+		return
+	}
+	c.removeReal(c.frequent.Back())
+}
+
+// removeReal fully removes a real (recent or frequent) entry and fires
+// OnEvicted; it does not add the key to the ghost list.
+func (c *TwoQueueCache) removeReal(ele *list.Element) {
+	e := ele.Value.(*tqEntry)
+	if e.inFreq {
+		c.frequent.Remove(ele)
+	} else {
+		c.recent.Remove(ele)
+	}
+	delete(c.cache, e.key)
+	if c.OnEvicted != nil {
+		c.OnEvicted(e.key, e.value)
+	}
+}
+
+// Len returns the number of items currently cached (recent + frequent;
+// the ghost list is bookkeeping, not live data).
+func (c *TwoQueueCache) Len() int {
+	c.RLock()
+	defer c.RUnlock()
+	return c.recent.Len() + c.frequent.Len()
+}
+
+// Keys return all the keys in cache, recent before frequent.
+func (c *TwoQueueCache) Keys() []interface{} {
+	c.Lock()
+	defer c.Unlock()
+	keys := make([]interface{}, 0, c.recent.Len()+c.frequent.Len())
+	for _, lst := range []*list.List{c.recent, c.frequent} {
+		for ele := lst.Front(); ele != nil; {
+			next := ele.Next()
+			e := ele.Value.(*tqEntry)
+			if e.isExpired() {
+				c.removeReal(ele)
+			} else {
+				keys = append(keys, e.key)
+			}
+			ele = next
+		}
+	}
+	return keys
+}
+
+// Values return all the values in cache, recent before frequent.
+func (c *TwoQueueCache) Values() []interface{} {
+	c.Lock()
+	defer c.Unlock()
+	values := make([]interface{}, 0, c.recent.Len()+c.frequent.Len())
+	for _, lst := range []*list.List{c.recent, c.frequent} {
+		for ele := lst.Front(); ele != nil; {
+			next := ele.Next()
+			e := ele.Value.(*tqEntry)
+			if e.isExpired() {
+				c.removeReal(ele)
+			} else {
+				values = append(values, e.value)
+			}
+			ele = next
+		}
+	}
+	return values
+}
+
+// Flush remove all the keys in cache, including the ghost list.
+func (c *TwoQueueCache) Flush() {
+	c.Lock()
+	defer c.Unlock()
+	c.recent = list.New()
+	c.frequent = list.New()
+	c.ghost = list.New()
+	c.cache = make(map[Key]*list.Element)
+	c.ghostIndex = make(map[Key]*list.Element)
+}
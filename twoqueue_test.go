@@ -0,0 +1,61 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTwoQueueCacheExpire(t *testing.T) {
+	c := NewTwoQueueCache(8, 1)
+	c.Set("a", 1)
+	time.Sleep(2 * time.Second)
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("a should have expired")
+	}
+}
+
+func TestTwoQueueCache(t *testing.T) {
+	c := NewTwoQueueCache(8, 0) // recent=2, frequent=4, ghost=4
+	c.Set("a", 1)
+	c.Set("b", 2)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("Get(a) = %v, %v; want 1, true", v, ok)
+	}
+	// a is now in frequent; fill recent past capacity to push b into ghost.
+	c.Set("c", 3)
+	c.Set("d", 4)
+	c.Set("e", 5)
+	if _, ok := c.Get("b"); ok {
+		t.Fatalf("b should have been demoted out of the live cache")
+	}
+	// Re-setting b should hit the ghost list and jump straight to frequent.
+	c.Set("b", 22)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("a should still be cached in frequent, got %v, %v", v, ok)
+	}
+	if v, ok := c.Get("b"); !ok || v != 22 {
+		t.Fatalf("Get(b) after ghost hit = %v, %v; want 22, true", v, ok)
+	}
+}
+
+func TestTwoQueueScanResistance(t *testing.T) {
+	const hot = 20
+	c := NewTwoQueueCache(40, 0)
+	for i := 0; i < hot; i++ {
+		c.Set(i, i)
+		c.Get(i) // promote the hot set into frequent
+	}
+	// A long scan of one-off keys should not evict the frequent hot set.
+	for i := 1000; i < 1000+500; i++ {
+		c.Set(i, i)
+	}
+	hits := 0
+	for i := 0; i < hot; i++ {
+		if _, ok := c.Get(i); ok {
+			hits++
+		}
+	}
+	if hits != hot {
+		t.Fatalf("scan evicted %d/%d of the hot set", hot-hits, hot)
+	}
+}